@@ -4,7 +4,8 @@ import (
 	"fmt"
 	"github.com/spf13/cobra"
 	"github.com/wattsmainsanglais/naturedopes-cli/pkg/config"
-	"reflect"
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/output"
+	"os"
 )
 
 var configCmd = &cobra.Command{
@@ -20,7 +21,7 @@ var setCmd = &cobra.Command{
 		key := args[0]
 		value := args[1]
 
-		err := config.Set(key, value)
+		err := config.SetInProfile(profileOverride, key, value)
 		if err != nil {
 			fmt.Printf("could not set: %v\n", err)
 			return
@@ -38,7 +39,7 @@ var getCmd = &cobra.Command{
 	Run: func(command *cobra.Command, args []string) {
 		key := args[0]
 
-		value, err := config.Get(key)
+		value, err := config.GetFromProfile(profileOverride, key)
 		if err != nil {
 			fmt.Printf("could not get: %v\n", err)
 			return
@@ -59,12 +60,60 @@ var listCmd = &cobra.Command{
 			return
 		}
 
-		values := reflect.ValueOf(*currentConfig)
-		types := values.Type()
+		format, err := resolveOutputFormat()
+		if err != nil {
+			fmt.Printf("invalid output format: %v\n", err)
+			return
+		}
+
+		if err := output.Config(os.Stdout, format, currentConfig); err != nil {
+			fmt.Printf("could not render config: %v\n", err)
+		}
+	},
+}
+
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Verify connectivity to the API using the resolved profile",
+	Args:  cobra.ExactArgs(0),
+	Run: func(command *cobra.Command, args []string) {
+		profile, err := resolveProfile()
+		if err != nil {
+			fmt.Printf("could not resolve profile: %v\n", err)
+			return
+		}
 
-		for i := 0; i < values.NumField(); i++ {
-			fmt.Println(types.Field(i).Tag.Get("json"), ": ", values.Field(i))
+		if !checkApiKey(profile.ApiKey) {
+			return
 		}
+
+		client, err := newClientFromProfile(profile)
+		if err != nil {
+			fmt.Printf("could not build api client: %v\n", err)
+			return
+		}
+
+		if _, err := client.ListKeys(); err != nil {
+			fmt.Printf("connection test failed: %v\n", err)
+			return
+		}
+
+		fmt.Println("Connection test succeeded")
+	},
+}
+
+var migrateSecretsCmd = &cobra.Command{
+	Use:   "migrate-secrets",
+	Short: "Move the resolved profile's plaintext api-key into the OS keychain (or encrypted file fallback)",
+	Args:  cobra.ExactArgs(0),
+	Run: func(command *cobra.Command, args []string) {
+		ref, err := config.MigrateApiKeyToStore(profileOverride)
+		if err != nil {
+			fmt.Printf("could not migrate api-key: %v\n", err)
+			return
+		}
+
+		fmt.Printf("api-key moved to secure storage, config.json now references %s\n", ref)
 	},
 }
 
@@ -73,4 +122,6 @@ func init() {
 	configCmd.AddCommand(setCmd)
 	configCmd.AddCommand(getCmd)
 	configCmd.AddCommand(listCmd)
+	configCmd.AddCommand(testCmd)
+	configCmd.AddCommand(migrateSecretsCmd)
 }
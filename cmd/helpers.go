@@ -3,8 +3,24 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/api"
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/config"
 )
 
+// newClientFromProfile builds an api.Client for profile, wiring through its
+// TLS settings so every command talks to the backend the same way.
+func newClientFromProfile(profile *config.Profile) (*api.Client, error) {
+	return api.NewClient(api.ClientConfig{
+		BaseUrl:            profile.ApiURL,
+		APIKey:             profile.ApiKey,
+		CertFile:           profile.TLSCertFile,
+		KeyFile:            profile.TLSKeyFile,
+		CAFile:             profile.TLSCAFile,
+		InsecureSkipVerify: profile.TLSInsecure,
+	})
+}
+
 func checkApiKey(apiKey string) bool {
 	if apiKey == "" {
 		fmt.Println("Error: No API key configured.")
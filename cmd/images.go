@@ -4,7 +4,8 @@ import (
 	"fmt"
 	"github.com/spf13/cobra"
 	"github.com/wattsmainsanglais/naturedopes-cli/pkg/api"
-	"github.com/wattsmainsanglais/naturedopes-cli/pkg/config"
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/output"
+	"os"
 	"strconv"
 )
 
@@ -18,14 +19,21 @@ var listImagesCmd = &cobra.Command{
 	Short: "Get list of images",
 	Args:  cobra.ExactArgs(0),
 	Run: func(command *cobra.Command, args []string) {
-		baseUrl, _ := config.Get("api-url")
-		key, _ := config.Get("api-key")
+		profile, err := resolveProfile()
+		if err != nil {
+			fmt.Printf("could not resolve profile: %v\n", err)
+			return
+		}
 
-		if !checkApiKey(key) {
+		if !checkApiKey(profile.ApiKey) {
 			return
 		}
 
-		client := api.NewClient(baseUrl, key)
+		client, err := newClientFromProfile(profile)
+		if err != nil {
+			fmt.Printf("could not build api client: %v\n", err)
+			return
+		}
 
 		resp, err := client.ListImages()
 		if err != nil {
@@ -33,10 +41,15 @@ var listImagesCmd = &cobra.Command{
 			return
 		}
 
-		for _, image := range resp {
-			fmt.Printf("name: %s, gps_long: %f, gps_lat: %f, image_path: %s\n", image.SpeciesName, image.GpsLong, image.GpsLat, image.ImagePath)
+		format, err := resolveOutputFormat()
+		if err != nil {
+			fmt.Printf("invalid output format: %v\n", err)
+			return
 		}
 
+		if err := output.Images(os.Stdout, format, resp); err != nil {
+			fmt.Printf("could not render images: %v\n", err)
+		}
 	},
 }
 
@@ -52,13 +65,20 @@ var getImageCmd = &cobra.Command{
 			return
 		}
 
-		baseUrl, _ := config.Get("api-url")
-		key, _ := config.Get("api-key")
+		profile, err := resolveProfile()
+		if err != nil {
+			fmt.Printf("could not resolve profile: %v\n", err)
+			return
+		}
 
-		if !checkApiKey(key) {
+		if !checkApiKey(profile.ApiKey) {
+			return
+		}
+		client, err := newClientFromProfile(profile)
+		if err != nil {
+			fmt.Printf("could not build api client: %v\n", err)
 			return
 		}
-		client := api.NewClient(baseUrl, key)
 
 		image, err := client.GetImage(integer)
 		if err != nil {
@@ -66,8 +86,15 @@ var getImageCmd = &cobra.Command{
 			return
 		}
 
-		fmt.Printf("id:%d name: %s, gps_long: %f, gps_lat: %f, image_path: %s, user_id: %d\n", image.ID, image.SpeciesName, image.GpsLong, image.GpsLat, image.ImagePath, image.UserID)
+		format, err := resolveOutputFormat()
+		if err != nil {
+			fmt.Printf("invalid output format: %v\n", err)
+			return
+		}
 
+		if err := output.Image(os.Stdout, format, image); err != nil {
+			fmt.Printf("could not render image: %v\n", err)
+		}
 	},
 }
 
@@ -85,13 +112,20 @@ var searchImagesCmd = &cobra.Command{
 			return
 		}
 
-		baseUrl, _ := config.Get("api-url")
-		key, _ := config.Get("api-key")
+		profile, err := resolveProfile()
+		if err != nil {
+			fmt.Printf("could not resolve profile: %v\n", err)
+			return
+		}
 
-		if !checkApiKey(key) {
+		if !checkApiKey(profile.ApiKey) {
+			return
+		}
+		client, err := newClientFromProfile(profile)
+		if err != nil {
+			fmt.Printf("could not build api client: %v\n", err)
 			return
 		}
-		client := api.NewClient(baseUrl, key)
 
 		images, err := client.SearchImages(name, idInt)
 		if err != nil {
@@ -99,10 +133,15 @@ var searchImagesCmd = &cobra.Command{
 			return
 		}
 
-		for _, i := range images {
-			fmt.Printf("id:%d species_name: %s, gps_long: %f, gps_lat: %f, image_path: %s user_id: %d\n", i.ID, i.SpeciesName, i.GpsLong, i.GpsLat, i.ImagePath, i.UserID)
+		format, err := resolveOutputFormat()
+		if err != nil {
+			fmt.Printf("invalid output format: %v\n", err)
+			return
 		}
 
+		if err := output.Images(os.Stdout, format, images); err != nil {
+			fmt.Printf("could not render images: %v\n", err)
+		}
 	},
 }
 
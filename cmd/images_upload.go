@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/exif"
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/models"
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/output"
+)
+
+var (
+	uploadSpecies string
+	uploadLat     float64
+	uploadLong    float64
+
+	uploadDirSpecies     string
+	uploadDirConcurrency int
+	uploadDirRecursive   bool
+)
+
+var uploadImageCmd = &cobra.Command{
+	Use:   "upload <path>",
+	Short: "Upload an image, auto-detecting GPS coordinates from EXIF when --lat/--long are omitted",
+	Args:  cobra.ExactArgs(1),
+	Run: func(command *cobra.Command, args []string) {
+		path := args[0]
+
+		profile, err := resolveProfile()
+		if err != nil {
+			fmt.Printf("could not resolve profile: %v\n", err)
+			return
+		}
+
+		if !checkApiKey(profile.ApiKey) {
+			return
+		}
+
+		client, err := newClientFromProfile(profile)
+		if err != nil {
+			fmt.Printf("could not build api client: %v\n", err)
+			return
+		}
+
+		lat, long, err := resolveCoordinates(path, uploadLat, uploadLong, command.Flags().Changed("lat"), command.Flags().Changed("long"))
+		if err != nil {
+			fmt.Printf("could not resolve GPS coordinates: %v\n", err)
+			return
+		}
+
+		image, err := client.UploadImage(path, uploadSpecies, lat, long)
+		if err != nil {
+			fmt.Printf("could not upload image: %v\n", err)
+			return
+		}
+
+		format, err := resolveOutputFormat()
+		if err != nil {
+			fmt.Printf("invalid output format: %v\n", err)
+			return
+		}
+
+		if err := output.Image(os.Stdout, format, image); err != nil {
+			fmt.Printf("could not render image: %v\n", err)
+		}
+	},
+}
+
+var uploadDirCmd = &cobra.Command{
+	Use:   "upload-dir <dir>",
+	Short: "Upload every image in a directory, auto-detecting GPS coordinates from EXIF",
+	Args:  cobra.ExactArgs(1),
+	Run: func(command *cobra.Command, args []string) {
+		dir := args[0]
+
+		paths, err := collectImagePaths(dir, uploadDirRecursive)
+		if err != nil {
+			fmt.Printf("could not list images: %v\n", err)
+			return
+		}
+
+		if len(paths) == 0 {
+			fmt.Println("No image files found")
+			return
+		}
+
+		format, err := resolveOutputFormat()
+		if err != nil {
+			fmt.Printf("invalid output format: %v\n", err)
+			return
+		}
+
+		profile, err := resolveProfile()
+		if err != nil {
+			fmt.Printf("could not resolve profile: %v\n", err)
+			return
+		}
+
+		if !checkApiKey(profile.ApiKey) {
+			return
+		}
+
+		client, err := newClientFromProfile(profile)
+		if err != nil {
+			fmt.Printf("could not build api client: %v\n", err)
+			return
+		}
+
+		concurrency := uploadDirConcurrency
+		if concurrency < 1 {
+			concurrency = 1
+		}
+
+		jobs := make(chan string)
+		type uploadResult struct {
+			path  string
+			image *models.Image
+			err   error
+		}
+		results := make(chan uploadResult)
+
+		var wg sync.WaitGroup
+		for i := 0; i < concurrency; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for path := range jobs {
+					lat, long, err := resolveCoordinates(path, 0, 0, false, false)
+					if err != nil {
+						results <- uploadResult{path: path, err: err}
+						continue
+					}
+
+					image, err := client.UploadImage(path, uploadDirSpecies, lat, long)
+					results <- uploadResult{path: path, image: image, err: err}
+				}
+			}()
+		}
+
+		go func() {
+			for _, path := range paths {
+				jobs <- path
+			}
+			close(jobs)
+		}()
+
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		var uploaded []models.Image
+		failed := 0
+		done := 0
+		for res := range results {
+			done++
+			if res.err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "[%d/%d] failed: %s: %v\n", done, len(paths), res.path, res.err)
+				continue
+			}
+
+			uploaded = append(uploaded, *res.image)
+			fmt.Fprintf(os.Stderr, "[%d/%d] uploaded: %s\n", done, len(paths), res.path)
+		}
+
+		fmt.Fprintf(os.Stderr, "Uploaded %d/%d images (%d failed)\n", len(uploaded), len(paths), failed)
+
+		if err := output.Images(os.Stdout, format, uploaded); err != nil {
+			fmt.Printf("could not render results: %v\n", err)
+		}
+	},
+}
+
+// resolveCoordinates returns the GPS coordinates to upload an image with:
+// flag values where the user supplied --lat/--long, falling back to the
+// image's EXIF GPS data for whichever of the two wasn't supplied.
+func resolveCoordinates(path string, lat, long float64, latSet, longSet bool) (float64, float64, error) {
+	if latSet && longSet {
+		return lat, long, nil
+	}
+
+	coords, err := exif.ExtractGPS(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("no --lat/--long supplied and could not read GPS from EXIF: %w", err)
+	}
+
+	resultLat, resultLong := coords.Lat, coords.Long
+	if latSet {
+		resultLat = lat
+	}
+	if longSet {
+		resultLong = long
+	}
+
+	return resultLat, resultLong, nil
+}
+
+// collectImagePaths lists JPEG/HEIC files directly under dir, recursing
+// into subdirectories when recursive is true.
+func collectImagePaths(dir string, recursive bool) ([]string, error) {
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read directory: %w", err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		fullPath := filepath.Join(dir, entry.Name())
+
+		if entry.IsDir() {
+			if recursive {
+				nested, err := collectImagePaths(fullPath, recursive)
+				if err != nil {
+					return nil, err
+				}
+				paths = append(paths, nested...)
+			}
+			continue
+		}
+
+		if isImageFile(entry.Name()) {
+			paths = append(paths, fullPath)
+		}
+	}
+
+	return paths, nil
+
+}
+
+func isImageFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".jpg", ".jpeg", ".heic", ".heif":
+		return true
+	default:
+		return false
+	}
+}
+
+func init() {
+	uploadImageCmd.Flags().StringVar(&uploadSpecies, "species", "", "Species name for the uploaded image")
+	uploadImageCmd.Flags().Float64Var(&uploadLat, "lat", 0, "GPS latitude (auto-detected from EXIF when omitted)")
+	uploadImageCmd.Flags().Float64Var(&uploadLong, "long", 0, "GPS longitude (auto-detected from EXIF when omitted)")
+
+	uploadDirCmd.Flags().StringVar(&uploadDirSpecies, "species", "", "Species name for every uploaded image")
+	uploadDirCmd.Flags().IntVar(&uploadDirConcurrency, "concurrency", 4, "Number of images to upload concurrently")
+	uploadDirCmd.Flags().BoolVar(&uploadDirRecursive, "recursive", false, "Recurse into subdirectories")
+
+	imagesCmd.AddCommand(uploadImageCmd)
+	imagesCmd.AddCommand(uploadDirCmd)
+}
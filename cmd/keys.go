@@ -8,7 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/wattsmainsanglais/naturedopes-cli/pkg/api"
-	"github.com/wattsmainsanglais/naturedopes-cli/pkg/config"
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/output"
 )
 
 var keysCmnd = &cobra.Command{
@@ -21,9 +21,16 @@ var listKeys = &cobra.Command{
 	Short: "List api keys",
 	Args:  cobra.ExactArgs(0),
 	Run: func(command *cobra.Command, args []string) {
-		baseUrl, _ := config.Get("api-url")
-		key, _ := config.Get("api-key")
-		client := api.NewClient(baseUrl, key)
+		profile, err := resolveProfile()
+		if err != nil {
+			fmt.Printf("could not resolve profile: %v\n", err)
+			return
+		}
+		client, err := newClientFromProfile(profile)
+		if err != nil {
+			fmt.Printf("could not build api client: %v\n", err)
+			return
+		}
 
 		resp, error := client.ListKeys()
 		if error != nil {
@@ -31,10 +38,15 @@ var listKeys = &cobra.Command{
 			return
 		}
 
-		for _, k := range resp {
-			fmt.Printf("id: %v , name: %v, key: %v..., created: %v, expires: %v, last used: %v, revoked %v\n", k.ID, k.Name, k.Key[:8], k.CreatedAt, k.ExpiresAt, k.LastUsed, k.Revoked)
+		format, err := resolveOutputFormat()
+		if err != nil {
+			fmt.Printf("invalid output format: %v\n", err)
+			return
 		}
 
+		if err := output.ApiKeys(os.Stdout, format, resp); err != nil {
+			fmt.Printf("could not render api keys: %v\n", err)
+		}
 	},
 }
 
@@ -45,9 +57,16 @@ var generateKey = &cobra.Command{
 	Run: func(command *cobra.Command, args []string) {
 		name := args[0]
 
-		baseUrl, _ := config.Get("api-url")
-		key, _ := config.Get("api-key")
-		client := api.NewClient(baseUrl, key)
+		profile, err := resolveProfile()
+		if err != nil {
+			fmt.Printf("could not resolve profile: %v\n", err)
+			return
+		}
+		client, err := newClientFromProfile(profile)
+		if err != nil {
+			fmt.Printf("could not build api client: %v\n", err)
+			return
+		}
 
 		resp, error := client.GenerateKey(name)
 		if error != nil {
@@ -55,8 +74,15 @@ var generateKey = &cobra.Command{
 			return
 		}
 
-		fmt.Printf("api key %v generated, key value: %v , please save this key now (you won't be able to see it again). key will expire %v,", resp.Name, resp.Key, resp.ExpiresAt)
+		format, err := resolveOutputFormat()
+		if err != nil {
+			fmt.Printf("invalid output format: %v\n", err)
+			return
+		}
 
+		if err := output.ApiKey(os.Stdout, format, resp); err != nil {
+			fmt.Printf("could not render api key: %v\n", err)
+		}
 	},
 }
 
@@ -65,10 +91,13 @@ var revokeKey = &cobra.Command{
 	Short: "revoke the configured api key",
 	Args:  cobra.ExactArgs(0),
 	Run: func(command *cobra.Command, args []string) {
-		baseUrl, _ := config.Get("api-url")
-		key, _ := config.Get("api-key")
+		profile, err := resolveProfile()
+		if err != nil {
+			fmt.Printf("could not resolve profile: %v\n", err)
+			return
+		}
 
-		if key == "" {
+		if profile.ApiKey == "" {
 			fmt.Println("Error: No API key configured. Use 'config set api-key <key>' first.")
 			return
 		}
@@ -83,7 +112,11 @@ var revokeKey = &cobra.Command{
 			return
 		}
 
-		client := api.NewClient(baseUrl, key)
+		client, err := newClientFromProfile(profile)
+		if err != nil {
+			fmt.Printf("could not build api client: %v\n", err)
+			return
+		}
 
 		error := client.RevokeKey()
 		if error != nil {
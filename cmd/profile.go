@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/config"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named API connection profiles",
+}
+
+var createProfileCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a new profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(command *cobra.Command, args []string) {
+		name := args[0]
+
+		err := config.CreateProfile(name, apiUrl, apiKey)
+		if err != nil {
+			fmt.Printf("could not create profile: %v\n", err)
+			return
+		}
+
+		fmt.Printf("Profile %q created\n", name)
+	},
+}
+
+var useProfileCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the active profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(command *cobra.Command, args []string) {
+		name := args[0]
+
+		err := config.UseProfile(name)
+		if err != nil {
+			fmt.Printf("could not switch profile: %v\n", err)
+			return
+		}
+
+		fmt.Printf("Active profile is now %q\n", name)
+	},
+}
+
+var listProfilesCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all configured profiles",
+	Args:  cobra.ExactArgs(0),
+	Run: func(command *cobra.Command, args []string) {
+		names, err := config.ListProfiles()
+		if err != nil {
+			fmt.Printf("could not list profiles: %v\n", err)
+			return
+		}
+
+		for _, name := range names {
+			fmt.Println(name)
+		}
+	},
+}
+
+var deleteProfileCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(command *cobra.Command, args []string) {
+		name := args[0]
+
+		err := config.DeleteProfile(name)
+		if err != nil {
+			fmt.Printf("could not delete profile: %v\n", err)
+			return
+		}
+
+		fmt.Printf("Profile %q deleted\n", name)
+	},
+}
+
+var showProfileCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show a profile's connection details (defaults to the active profile)",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(command *cobra.Command, args []string) {
+		name := ""
+		if len(args) == 1 {
+			name = args[0]
+		}
+
+		profile, err := config.ShowProfile(name)
+		if err != nil {
+			fmt.Printf("could not show profile: %v\n", err)
+			return
+		}
+
+		fmt.Printf("api_url: %s\napi_key: %s\n", profile.ApiURL, profile.ApiKey)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(createProfileCmd)
+	profileCmd.AddCommand(useProfileCmd)
+	profileCmd.AddCommand(listProfilesCmd)
+	profileCmd.AddCommand(deleteProfileCmd)
+	profileCmd.AddCommand(showProfileCmd)
+}
@@ -5,11 +5,15 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/config"
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/output"
 )
 
 var (
-	apiKey string
-	apiUrl string
+	apiKey          string
+	apiUrl          string
+	outputFormat    string
+	profileOverride string
 )
 
 var rootCmd = &cobra.Command{
@@ -36,4 +40,38 @@ func init() {
 	//global persistant flags
 	rootCmd.PersistentFlags().StringVar(&apiUrl, "api-url", "http://localhost:8080", "API base Url")
 	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "API key for auth")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "", "Output format: human, json, csv or yaml (overrides the configured default)")
+	rootCmd.PersistentFlags().StringVar(&profileOverride, "profile", "", "Profile to use for this invocation (overrides the active profile without changing it)")
+}
+
+// resolveProfile returns the API connection details for this invocation:
+// the --profile override when set, otherwise the active profile. It never
+// mutates the on-disk active profile, and resolves a keyring-backed
+// api-key into its literal value.
+func resolveProfile() (*config.Profile, error) {
+	profile, err := config.ShowProfile(profileOverride)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := config.GetFromProfile(profileOverride, "api-key")
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := *profile
+	resolved.ApiKey = apiKey
+	return &resolved, nil
+}
+
+// resolveOutputFormat returns the format requested on the command line via
+// --output, falling back to the configured default and finally to human. An
+// invalid format is reported rather than silently defaulted.
+func resolveOutputFormat() (output.Format, error) {
+	value := outputFormat
+	if value == "" {
+		value, _ = config.Get("output")
+	}
+
+	return output.ParseFormat(value)
 }
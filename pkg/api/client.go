@@ -2,40 +2,147 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
 )
 
 type Client struct {
-	BaseUrl    string
-	APIKey     string
-	HTTPClient *http.Client
+	BaseUrl            string
+	APIKey             string
+	HTTPClient         *http.Client
+	CertFile           string
+	KeyFile            string
+	CAFile             string
+	InsecureSkipVerify bool
+
+	// requestBaseUrl is what requests are actually built against. It's
+	// equal to BaseUrl except for unix:// URLs, where the transport dials
+	// the socket directly and requests use a placeholder HTTP host.
+	requestBaseUrl string
+}
+
+// ClientConfig holds everything needed to construct a Client: where the
+// Nature Dopes API lives (plain http(s):// or unix:// for a Unix domain
+// socket) and, optionally, the mTLS material to present to it.
+type ClientConfig struct {
+	BaseUrl            string
+	APIKey             string
+	CertFile           string
+	KeyFile            string
+	CAFile             string
+	InsecureSkipVerify bool
+}
+
+// NewClient builds a Client from cfg. It returns an error if BaseUrl can't
+// be parsed or the TLS material can't be loaded, so misconfiguration is
+// surfaced immediately instead of on the first request.
+func NewClient(cfg ClientConfig) (*Client, error) {
+
+	parsed, err := url.Parse(cfg.BaseUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid api-url %q: %w", cfg.BaseUrl, err)
+	}
+
+	client := &Client{
+		BaseUrl:            cfg.BaseUrl,
+		APIKey:             cfg.APIKey,
+		CertFile:           cfg.CertFile,
+		KeyFile:            cfg.KeyFile,
+		CAFile:             cfg.CAFile,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		requestBaseUrl:     cfg.BaseUrl,
+	}
+
+	transport := &http.Transport{}
+
+	if parsed.Scheme == "unix" {
+		socketPath := parsed.Path
+		if socketPath == "" {
+			socketPath = parsed.Opaque
+		}
+
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socketPath)
+		}
+		client.requestBaseUrl = "http://unix"
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" || cfg.CAFile != "" || cfg.InsecureSkipVerify {
+		tlsConfig, err := buildTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	client.HTTPClient = &http.Client{Transport: transport}
+
+	return client, nil
 }
 
-func NewClient(BaseUrl string, APIKey string) *Client {
-	return &Client{
-		BaseUrl:    BaseUrl,
-		APIKey:     APIKey,
-		HTTPClient: &http.Client{},
+func buildTLSConfig(cfg ClientConfig) (*tls.Config, error) {
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if cfg.CAFile != "" {
+		caCert, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read CA file: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("could not parse CA file: %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
 	}
+
+	return tlsConfig, nil
 }
 
 func (c *Client) doRequest(method string, path string, body []byte) ([]byte, error) {
 
-	url := c.BaseUrl + path
 	var reqBody io.Reader = nil
+	contentType := ""
 	if body != nil {
 		reqBody = bytes.NewBuffer(body)
+		contentType = "application/json"
 	}
 
-	req, err := http.NewRequest(method, url, reqBody)
+	return c.doRequestStream(method, path, reqBody, contentType)
+
+}
+
+// doRequestStream is like doRequest but takes body as an io.Reader so
+// large payloads (e.g. image uploads) don't have to be buffered fully
+// into memory before being sent.
+func (c *Client) doRequestStream(method string, path string, body io.Reader, contentType string) ([]byte, error) {
+
+	url := c.requestBaseUrl + path
+
+	req, err := http.NewRequest(method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("could not create http request err: %w", err)
 	}
 
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
 	}
 
 	if c.APIKey != "" {
@@ -4,7 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/wattsmainsanglais/naturedopes-cli/pkg/models"
+	"io"
+	"mime/multipart"
 	"net/url"
+	"os"
+	"path/filepath"
 	"strconv"
 )
 
@@ -72,3 +76,60 @@ func (c *Client) SearchImages(species string, userID int) ([]models.Image, error
 	return images, nil
 
 }
+
+// UploadImage POSTs the image file at path, along with its species and GPS
+// coordinates, as multipart/form-data. The file is streamed rather than
+// buffered fully into memory so large uploads stay cheap.
+func (c *Client) UploadImage(path string, species string, lat, long float64) (*models.Image, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open image file: %w", err)
+	}
+	defer file.Close()
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+
+	go func() {
+		defer pw.Close()
+		defer writer.Close()
+
+		part, err := writer.CreateFormFile("image", filepath.Base(path))
+		if err != nil {
+			pw.CloseWithError(fmt.Errorf("could not create form file: %w", err))
+			return
+		}
+
+		if _, err := io.Copy(part, file); err != nil {
+			pw.CloseWithError(fmt.Errorf("could not copy image data: %w", err))
+			return
+		}
+
+		fields := map[string]string{
+			"species_name": species,
+			"gps_lat":      strconv.FormatFloat(lat, 'f', -1, 64),
+			"gps_long":     strconv.FormatFloat(long, 'f', -1, 64),
+		}
+
+		for key, value := range fields {
+			if err := writer.WriteField(key, value); err != nil {
+				pw.CloseWithError(fmt.Errorf("could not write form field %q: %w", key, err))
+				return
+			}
+		}
+	}()
+
+	resp, err := c.doRequestStream("POST", "/images", pr, writer.FormDataContentType())
+	if err != nil {
+		return nil, fmt.Errorf("could not upload image: %w", err)
+	}
+
+	var image models.Image
+	if err := json.Unmarshal(resp, &image); err != nil {
+		return nil, fmt.Errorf("could not unmarshal response: %w", err)
+	}
+
+	return &image, nil
+
+}
@@ -5,11 +5,36 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/secrets"
 )
 
+// DefaultProfile is the name profiles are migrated into when an existing
+// flat, pre-profile config.json is loaded.
+const DefaultProfile = "default"
+
+// keyringRefPrefix marks a Profile.ApiKey value as a reference into the
+// secret store rather than a literal key: "keyring:naturedopes-cli/<account>".
+const keyringRefPrefix = "keyring:naturedopes-cli/"
+
+// Profile holds the API connection details for a single named environment
+// (e.g. "default", "staging", "prod").
+type Profile struct {
+	ApiURL      string `json:"api_url"`
+	ApiKey      string `json:"api_key"`
+	TLSCertFile string `json:"tls_cert,omitempty"`
+	TLSKeyFile  string `json:"tls_key,omitempty"`
+	TLSCAFile   string `json:"tls_ca,omitempty"`
+	TLSInsecure bool   `json:"tls_insecure,omitempty"`
+}
+
 type Config struct {
-	ApiURL string `json:"api_url"`
-	ApiKey string `json:"api_key"`
+	Profiles      map[string]*Profile `json:"profiles"`
+	ActiveProfile string              `json:"active_profile"`
+	Output        string              `json:"output"`
 }
 
 func getConfigFilePath() (string, error) {
@@ -25,6 +50,14 @@ func getConfigFilePath() (string, error) {
 
 }
 
+// legacyConfig mirrors the pre-profile, single ApiURL/ApiKey config.json
+// shape so it can be migrated into a default profile.
+type legacyConfig struct {
+	ApiURL string `json:"api_url"`
+	ApiKey string `json:"api_key"`
+	Output string `json:"output"`
+}
+
 func Load() (*Config, error) {
 
 	path, err := getConfigFilePath()
@@ -39,8 +72,13 @@ func Load() (*Config, error) {
 		}
 
 		return &Config{
-			ApiURL: apiUrl,
-			ApiKey: os.Getenv("API_KEY"),
+			Profiles: map[string]*Profile{
+				DefaultProfile: {
+					ApiURL: apiUrl,
+					ApiKey: os.Getenv("API_KEY"),
+				},
+			},
+			ActiveProfile: DefaultProfile,
 		}, nil
 	}
 
@@ -56,6 +94,28 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("couldn't unmarshal JSON: %w", err)
 	}
 
+	if len(config.Profiles) == 0 {
+		var legacy legacyConfig
+		if err := json.Unmarshal(fileContent, &legacy); err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal legacy JSON: %w", err)
+		}
+
+		config.Profiles = map[string]*Profile{
+			DefaultProfile: {
+				ApiURL: legacy.ApiURL,
+				ApiKey: legacy.ApiKey,
+			},
+		}
+		config.ActiveProfile = DefaultProfile
+		if config.Output == "" {
+			config.Output = legacy.Output
+		}
+
+		if err := config.Save(); err != nil {
+			return nil, fmt.Errorf("could not migrate legacy config: %w", err)
+		}
+	}
+
 	return &config, nil
 
 }
@@ -88,45 +148,302 @@ func (config *Config) Save() error {
 
 }
 
-func Set(key, value string) error {
+// profile resolves the named profile, falling back to the active profile
+// when name is empty.
+func (config *Config) profile(name string) (*Profile, error) {
+	if name == "" {
+		name = config.ActiveProfile
+	}
+
+	profile, ok := config.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("no such profile: %s", name)
+	}
+
+	return profile, nil
+}
+
+// IsKeyringRef reports whether value is a reference into the secret store
+// rather than a literal API key.
+func IsKeyringRef(value string) bool {
+	return strings.HasPrefix(value, keyringRefPrefix)
+}
+
+func keyringAccount(profileName string) string {
+	return profileName + "-api-key"
+}
+
+func buildKeyringRef(profileName string) string {
+	return keyringRefPrefix + keyringAccount(profileName)
+}
+
+func keyringAccountFromRef(ref string) string {
+	return strings.TrimPrefix(ref, keyringRefPrefix)
+}
+
+func openSecretStore() (secrets.Store, error) {
+	return secrets.Open(os.Getenv("NATUREDOPES_CLI_PASSPHRASE"))
+}
+
+// resolveApiKey returns the literal API key for profile, fetching it from
+// the secret store if rawApiKey is a keyring reference.
+func resolveApiKey(rawApiKey string) (string, error) {
+	if !IsKeyringRef(rawApiKey) {
+		return rawApiKey, nil
+	}
+
+	store, err := openSecretStore()
+	if err != nil {
+		return "", fmt.Errorf("could not open secret store: %w", err)
+	}
+
+	key, err := store.Get(keyringAccountFromRef(rawApiKey))
+	if err != nil {
+		return "", fmt.Errorf("could not read api-key from secret store: %w", err)
+	}
+
+	return key, nil
+}
+
+// MigrateApiKeyToStore moves the named profile's (or the active profile's,
+// when profileName is empty) plaintext api-key into the secret store and
+// rewrites config.json to hold a keyring reference instead.
+func MigrateApiKeyToStore(profileName string) (string, error) {
+
+	currentConfig, err := Load()
+	if err != nil {
+		return "", fmt.Errorf("could not load config file: %w", err)
+	}
+
+	name := profileName
+	if name == "" {
+		name = currentConfig.ActiveProfile
+	}
+
+	profile, err := currentConfig.profile(name)
+	if err != nil {
+		return "", err
+	}
+
+	if IsKeyringRef(profile.ApiKey) {
+		return "", fmt.Errorf("api-key for profile %q is already stored in the secret store", name)
+	}
+
+	if profile.ApiKey == "" {
+		return "", fmt.Errorf("no api-key configured for profile %q", name)
+	}
+
+	store, err := openSecretStore()
+	if err != nil {
+		return "", fmt.Errorf("could not open secret store: %w", err)
+	}
+
+	account := keyringAccount(name)
+	if err := store.Set(account, profile.ApiKey); err != nil {
+		return "", fmt.Errorf("could not store api-key: %w", err)
+	}
+
+	ref := buildKeyringRef(name)
+	profile.ApiKey = ref
+
+	if err := currentConfig.Save(); err != nil {
+		return "", fmt.Errorf("could not save config file: %w", err)
+	}
+
+	return ref, nil
+
+}
+
+// SetInProfile sets key on the named profile (or the active profile, when
+// profileName is empty) and persists the change. The "output" key is not
+// profile-scoped and is stored on the top level config instead.
+func SetInProfile(profileName, key, value string) error {
 
 	currentConfig, err := Load()
 	if err != nil {
 		return fmt.Errorf("could not load config file: %w", err)
 	}
 
+	if key == "output" {
+		switch value {
+		case "human", "json", "csv", "yaml":
+			currentConfig.Output = value
+		default:
+			return fmt.Errorf("invalid output format: %s (want human, json, csv or yaml)", value)
+		}
+
+		return currentConfig.Save()
+	}
+
+	profile, err := currentConfig.profile(profileName)
+	if err != nil {
+		return err
+	}
+
 	switch key {
 	case "api-url":
-		currentConfig.ApiURL = value
+		profile.ApiURL = value
 	case "api-key":
-		currentConfig.ApiKey = value
+		profile.ApiKey = value
+	case "tls-cert":
+		profile.TLSCertFile = value
+	case "tls-key":
+		profile.TLSKeyFile = value
+	case "tls-ca":
+		profile.TLSCAFile = value
+	case "tls-insecure":
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid tls-insecure value: %s (want true or false)", value)
+		}
+		profile.TLSInsecure = insecure
 	default:
 		return fmt.Errorf("invalid key: %s", key)
 	}
 
-	err = currentConfig.Save()
-	if err != nil {
-		return fmt.Errorf("could not save config file: %w", err)
-	}
+	return currentConfig.Save()
 
-	return nil
+}
 
+// Set sets key on the active profile. It's a convenience wrapper around
+// SetInProfile for callers that don't need to target a specific profile.
+func Set(key, value string) error {
+	return SetInProfile("", key, value)
 }
 
-func Get(key string) (string, error) {
+// GetFromProfile reads key from the named profile (or the active profile,
+// when profileName is empty), without mutating the on-disk active profile.
+func GetFromProfile(profileName, key string) (string, error) {
 
 	currentConfig, err := Load()
 	if err != nil {
 		return "", fmt.Errorf("could not load config file: %w", err)
 	}
 
+	if key == "output" {
+		return currentConfig.Output, nil
+	}
+
+	profile, err := currentConfig.profile(profileName)
+	if err != nil {
+		return "", err
+	}
+
 	switch key {
 	case "api-url":
-		return currentConfig.ApiURL, nil
+		return profile.ApiURL, nil
 	case "api-key":
-		return currentConfig.ApiKey, nil
+		return resolveApiKey(profile.ApiKey)
+	case "tls-cert":
+		return profile.TLSCertFile, nil
+	case "tls-key":
+		return profile.TLSKeyFile, nil
+	case "tls-ca":
+		return profile.TLSCAFile, nil
+	case "tls-insecure":
+		return strconv.FormatBool(profile.TLSInsecure), nil
 	default:
 		return "", fmt.Errorf("invalid key: %s", key)
 	}
 
 }
+
+// Get reads key from the active profile. It's a convenience wrapper around
+// GetFromProfile for callers that don't need to target a specific profile.
+func Get(key string) (string, error) {
+	return GetFromProfile("", key)
+}
+
+// UseProfile makes name the active profile and persists the change.
+func UseProfile(name string) error {
+
+	currentConfig, err := Load()
+	if err != nil {
+		return fmt.Errorf("could not load config file: %w", err)
+	}
+
+	if _, ok := currentConfig.Profiles[name]; !ok {
+		return fmt.Errorf("no such profile: %s", name)
+	}
+
+	currentConfig.ActiveProfile = name
+
+	return currentConfig.Save()
+
+}
+
+// ListProfiles returns the names of all configured profiles, sorted.
+func ListProfiles() ([]string, error) {
+
+	currentConfig, err := Load()
+	if err != nil {
+		return nil, fmt.Errorf("could not load config file: %w", err)
+	}
+
+	names := make([]string, 0, len(currentConfig.Profiles))
+	for name := range currentConfig.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+
+}
+
+// CreateProfile adds a new named profile and persists the change.
+func CreateProfile(name, apiUrl, apiKey string) error {
+
+	currentConfig, err := Load()
+	if err != nil {
+		return fmt.Errorf("could not load config file: %w", err)
+	}
+
+	if currentConfig.Profiles == nil {
+		currentConfig.Profiles = map[string]*Profile{}
+	}
+
+	if _, exists := currentConfig.Profiles[name]; exists {
+		return fmt.Errorf("profile already exists: %s", name)
+	}
+
+	currentConfig.Profiles[name] = &Profile{ApiURL: apiUrl, ApiKey: apiKey}
+
+	return currentConfig.Save()
+
+}
+
+// DeleteProfile removes a named profile and persists the change. The
+// active profile cannot be deleted; switch to another profile first.
+func DeleteProfile(name string) error {
+
+	currentConfig, err := Load()
+	if err != nil {
+		return fmt.Errorf("could not load config file: %w", err)
+	}
+
+	if _, ok := currentConfig.Profiles[name]; !ok {
+		return fmt.Errorf("no such profile: %s", name)
+	}
+
+	if name == currentConfig.ActiveProfile {
+		return fmt.Errorf("cannot delete the active profile %q, switch profiles first", name)
+	}
+
+	delete(currentConfig.Profiles, name)
+
+	return currentConfig.Save()
+
+}
+
+// ShowProfile returns the named profile (or the active profile, when name
+// is empty).
+func ShowProfile(name string) (*Profile, error) {
+
+	currentConfig, err := Load()
+	if err != nil {
+		return nil, fmt.Errorf("could not load config file: %w", err)
+	}
+
+	return currentConfig.profile(name)
+
+}
@@ -0,0 +1,133 @@
+// Package exif reads GPS coordinates out of an image's EXIF metadata, so
+// uploads can auto-populate location when the user doesn't supply one.
+package exif
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	goexif "github.com/rwcarlsen/goexif/exif"
+	"github.com/rwcarlsen/goexif/tiff"
+)
+
+// Coordinates is a GPS position in signed decimal degrees.
+type Coordinates struct {
+	Lat  float64
+	Long float64
+}
+
+// heicExifMarker prefixes the embedded EXIF payload inside a HEIC
+// container's 'Exif' item, same as it does inside a JPEG APP1 segment.
+var heicExifMarker = []byte("Exif\x00\x00")
+
+// ExtractGPS reads GPSLatitude/GPSLongitude and their hemisphere refs from
+// path's EXIF metadata (JPEG or HEIC) and converts them to signed decimal
+// degrees, negating for S/W refs.
+func ExtractGPS(path string) (Coordinates, error) {
+
+	file, err := os.Open(path)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("could not open image file: %w", err)
+	}
+	defer file.Close()
+
+	raw, err := io.ReadAll(file)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("could not read image file: %w", err)
+	}
+
+	data, err := decode(raw)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("could not decode EXIF data: %w", err)
+	}
+
+	lat, err := decimalDegrees(data, goexif.GPSLatitude, goexif.GPSLatitudeRef, "S")
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("could not read GPS latitude: %w", err)
+	}
+
+	long, err := decimalDegrees(data, goexif.GPSLongitude, goexif.GPSLongitudeRef, "W")
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("could not read GPS longitude: %w", err)
+	}
+
+	return Coordinates{Lat: lat, Long: long}, nil
+
+}
+
+func decode(raw []byte) (*goexif.Exif, error) {
+	if isHEIC(raw) {
+		idx := bytes.Index(raw, heicExifMarker)
+		if idx == -1 {
+			return nil, fmt.Errorf("no EXIF data found in HEIC container")
+		}
+		return goexif.Decode(bytes.NewReader(raw[idx+len(heicExifMarker):]))
+	}
+
+	return goexif.Decode(bytes.NewReader(raw))
+}
+
+func isHEIC(raw []byte) bool {
+	if len(raw) < 12 || string(raw[4:8]) != "ftyp" {
+		return false
+	}
+
+	switch string(raw[8:12]) {
+	case "heic", "heix", "hevc", "heim", "heis", "hevm", "hevs", "mif1":
+		return true
+	default:
+		return false
+	}
+}
+
+func decimalDegrees(data *goexif.Exif, valueTag, refTag goexif.FieldName, negativeRef string) (float64, error) {
+
+	tag, err := data.Get(valueTag)
+	if err != nil {
+		return 0, err
+	}
+
+	decimal, err := dmsToDecimal(tag)
+	if err != nil {
+		return 0, err
+	}
+
+	if refTagValue, err := data.Get(refTag); err == nil {
+		if ref, err := refTagValue.StringVal(); err == nil && strings.EqualFold(ref, negativeRef) {
+			decimal = -decimal
+		}
+	}
+
+	return decimal, nil
+
+}
+
+// dmsToDecimal converts an EXIF GPS tag (3 rationals: degrees, minutes,
+// seconds) into decimal degrees.
+func dmsToDecimal(tag *tiff.Tag) (float64, error) {
+
+	degNum, degDenom, err := tag.Rat2(0)
+	if err != nil {
+		return 0, fmt.Errorf("could not read degrees: %w", err)
+	}
+
+	minNum, minDenom, err := tag.Rat2(1)
+	if err != nil {
+		return 0, fmt.Errorf("could not read minutes: %w", err)
+	}
+
+	secNum, secDenom, err := tag.Rat2(2)
+	if err != nil {
+		return 0, fmt.Errorf("could not read seconds: %w", err)
+	}
+
+	degrees := float64(degNum) / float64(degDenom)
+	minutes := float64(minNum) / float64(minDenom)
+	seconds := float64(secNum) / float64(secDenom)
+
+	return degrees + minutes/60 + seconds/3600, nil
+
+}
@@ -0,0 +1,234 @@
+// Package output renders CLI results (images, api keys, config) in one of
+// several user-selectable formats: human, json, csv or yaml.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/config"
+	"github.com/wattsmainsanglais/naturedopes-cli/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a supported rendering mode.
+type Format string
+
+const (
+	Human Format = "human"
+	JSON  Format = "json"
+	CSV   Format = "csv"
+	YAML  Format = "yaml"
+)
+
+// ParseFormat validates a user supplied format string, defaulting to Human
+// when empty.
+func ParseFormat(value string) (Format, error) {
+	switch Format(value) {
+	case "":
+		return Human, nil
+	case Human, JSON, CSV, YAML:
+		return Format(value), nil
+	default:
+		return "", fmt.Errorf("invalid output format: %s (want human, json, csv or yaml)", value)
+	}
+}
+
+// Images renders a list of images to w in the given format.
+func Images(w io.Writer, format Format, images []models.Image) error {
+	switch format {
+	case JSON:
+		return writeJSON(w, images)
+	case YAML:
+		return writeYAML(w, images)
+	case CSV:
+		header := []string{"id", "species_name", "gps_lat", "gps_long", "image_path", "user_id"}
+		rows := make([][]string, 0, len(images))
+		for _, img := range images {
+			rows = append(rows, []string{
+				fmt.Sprintf("%d", img.ID),
+				img.SpeciesName,
+				fmt.Sprintf("%f", img.GpsLat),
+				fmt.Sprintf("%f", img.GpsLong),
+				img.ImagePath,
+				fmt.Sprintf("%d", img.UserID),
+			})
+		}
+		return writeCSV(w, header, rows)
+	default:
+		for _, img := range images {
+			fmt.Fprintf(w, "id: %d, name: %s, gps_long: %f, gps_lat: %f, image_path: %s, user_id: %d\n", img.ID, img.SpeciesName, img.GpsLong, img.GpsLat, img.ImagePath, img.UserID)
+		}
+		return nil
+	}
+}
+
+// Image renders a single image to w in the given format.
+func Image(w io.Writer, format Format, image *models.Image) error {
+	switch format {
+	case JSON:
+		return writeJSON(w, image)
+	case YAML:
+		return writeYAML(w, image)
+	case CSV:
+		header := []string{"id", "species_name", "gps_lat", "gps_long", "image_path", "user_id"}
+		rows := [][]string{{
+			fmt.Sprintf("%d", image.ID),
+			image.SpeciesName,
+			fmt.Sprintf("%f", image.GpsLat),
+			fmt.Sprintf("%f", image.GpsLong),
+			image.ImagePath,
+			fmt.Sprintf("%d", image.UserID),
+		}}
+		return writeCSV(w, header, rows)
+	default:
+		fmt.Fprintf(w, "id:%d name: %s, gps_long: %f, gps_lat: %f, image_path: %s, user_id: %d\n", image.ID, image.SpeciesName, image.GpsLong, image.GpsLat, image.ImagePath, image.UserID)
+		return nil
+	}
+}
+
+// ApiKeys renders a list of api keys to w in the given format.
+func ApiKeys(w io.Writer, format Format, keys []models.ApiKey) error {
+	switch format {
+	case JSON:
+		return writeJSON(w, keys)
+	case YAML:
+		return writeYAML(w, keys)
+	case CSV:
+		header := []string{"id", "name", "key", "created_at", "expires_at", "last_used", "revoked"}
+		rows := make([][]string, 0, len(keys))
+		for _, k := range keys {
+			rows = append(rows, []string{
+				fmt.Sprintf("%d", k.ID),
+				k.Name,
+				keyPreview(k.Key),
+				k.CreatedAt,
+				k.ExpiresAt,
+				lastUsedString(k.LastUsed),
+				fmt.Sprintf("%t", k.Revoked),
+			})
+		}
+		return writeCSV(w, header, rows)
+	default:
+		for _, k := range keys {
+			fmt.Fprintf(w, "id: %v , name: %v, key: %v, created: %v, expires: %v, last used: %v, revoked %v\n", k.ID, k.Name, keyPreview(k.Key), k.CreatedAt, k.ExpiresAt, lastUsedString(k.LastUsed), k.Revoked)
+		}
+		return nil
+	}
+}
+
+// ApiKey renders a single api key to w in the given format.
+func ApiKey(w io.Writer, format Format, key *models.ApiKey) error {
+	switch format {
+	case JSON:
+		return writeJSON(w, key)
+	case YAML:
+		return writeYAML(w, key)
+	case CSV:
+		header := []string{"name", "key", "expires_at"}
+		rows := [][]string{{key.Name, key.Key, key.ExpiresAt}}
+		return writeCSV(w, header, rows)
+	default:
+		fmt.Fprintf(w, "api key %v generated, key value: %v , please save this key now (you won't be able to see it again). key will expire %v,", key.Name, key.Key, key.ExpiresAt)
+		return nil
+	}
+}
+
+// Config renders the current configuration to w in the given format. For
+// csv and human output the profiles map is walked explicitly (sorted by
+// name), since printing it with %v shows each *Profile as a pointer
+// address; json and yaml encode cfg as a whole.
+func Config(w io.Writer, format Format, cfg *config.Config) error {
+	switch format {
+	case JSON:
+		return writeJSON(w, cfg)
+	case YAML:
+		return writeYAML(w, cfg)
+	case CSV:
+		header := []string{"profile", "active", "api_url", "api_key", "tls_cert", "tls_key", "tls_ca", "tls_insecure"}
+		rows := make([][]string, 0, len(cfg.Profiles))
+		for _, name := range sortedProfileNames(cfg.Profiles) {
+			p := cfg.Profiles[name]
+			rows = append(rows, []string{
+				name,
+				fmt.Sprintf("%t", name == cfg.ActiveProfile),
+				p.ApiURL,
+				p.ApiKey,
+				p.TLSCertFile,
+				p.TLSKeyFile,
+				p.TLSCAFile,
+				fmt.Sprintf("%t", p.TLSInsecure),
+			})
+		}
+		return writeCSV(w, header, rows)
+	default:
+		fmt.Fprintln(w, "active_profile: ", cfg.ActiveProfile)
+		fmt.Fprintln(w, "output: ", cfg.Output)
+		for _, name := range sortedProfileNames(cfg.Profiles) {
+			p := cfg.Profiles[name]
+			fmt.Fprintf(w, "profile %s:\n", name)
+			fmt.Fprintln(w, "  api_url: ", p.ApiURL)
+			fmt.Fprintln(w, "  api_key: ", p.ApiKey)
+			fmt.Fprintln(w, "  tls_cert: ", p.TLSCertFile)
+			fmt.Fprintln(w, "  tls_key: ", p.TLSKeyFile)
+			fmt.Fprintln(w, "  tls_ca: ", p.TLSCAFile)
+			fmt.Fprintln(w, "  tls_insecure: ", p.TLSInsecure)
+		}
+		return nil
+	}
+}
+
+func sortedProfileNames(profiles map[string]*config.Profile) []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lastUsedString(lastUsed *string) string {
+	if lastUsed == nil {
+		return ""
+	}
+	return *lastUsed
+}
+
+// keyPreview returns the first 8 bytes of key (or the whole key, if
+// shorter) followed by "...", for display without leaking the full value.
+func keyPreview(key string) string {
+	n := 8
+	if len(key) < n {
+		n = len(key)
+	}
+	return key[:n] + "..."
+}
+
+func writeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func writeYAML(w io.Writer, v any) error {
+	enc := yaml.NewEncoder(w)
+	defer enc.Close()
+	return enc.Encode(v)
+}
+
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("could not write csv header: %w", err)
+	}
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("could not write csv row: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
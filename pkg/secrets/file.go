@@ -0,0 +1,201 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	scryptN   = 1 << 15
+	scryptR   = 8
+	scryptP   = 1
+	scryptLen = 32
+)
+
+// FileStore is the fallback Store used when no OS keychain is available.
+// Secrets are kept as a single JSON file at Path, AES-GCM encrypted with a
+// key derived from Passphrase via scrypt.
+type FileStore struct {
+	Path       string
+	Passphrase string
+}
+
+func NewFileStore(path, passphrase string) *FileStore {
+	return &FileStore{Path: path, Passphrase: passphrase}
+}
+
+// fileEnvelope is the on-disk shape of the secrets file: a scrypt salt, a
+// GCM nonce, and the ciphertext of the JSON-encoded secrets map.
+type fileEnvelope struct {
+	Salt       string `json:"salt"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+func (s *FileStore) Get(name string) (string, error) {
+
+	secretsMap, err := s.load()
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := secretsMap[name]
+	if !ok {
+		return "", fmt.Errorf("no secret named %q", name)
+	}
+
+	return value, nil
+
+}
+
+func (s *FileStore) Set(name, value string) error {
+
+	secretsMap, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	secretsMap[name] = value
+
+	return s.save(secretsMap)
+
+}
+
+func (s *FileStore) Delete(name string) error {
+
+	secretsMap, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	delete(secretsMap, name)
+
+	return s.save(secretsMap)
+
+}
+
+func (s *FileStore) load() (map[string]string, error) {
+
+	if _, err := os.Stat(s.Path); os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+
+	raw, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read secrets file: %w", err)
+	}
+
+	var envelope fileEnvelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("could not parse secrets file: %w", err)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(envelope.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode salt: %w", err)
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(envelope.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode nonce: %w", err)
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode ciphertext: %w", err)
+	}
+
+	gcm, err := s.cipher(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt secrets file (wrong passphrase?): %w", err)
+	}
+
+	var secretsMap map[string]string
+	if err := json.Unmarshal(plaintext, &secretsMap); err != nil {
+		return nil, fmt.Errorf("could not parse decrypted secrets: %w", err)
+	}
+
+	return secretsMap, nil
+
+}
+
+func (s *FileStore) save(secretsMap map[string]string) error {
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("could not generate salt: %w", err)
+	}
+
+	gcm, err := s.cipher(salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("could not generate nonce: %w", err)
+	}
+
+	plaintext, err := json.Marshal(secretsMap)
+	if err != nil {
+		return fmt.Errorf("could not marshal secrets: %w", err)
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	envelope := fileEnvelope{
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}
+
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal secrets file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0755); err != nil {
+		return fmt.Errorf("could not create secrets directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.Path, data, 0600); err != nil {
+		return fmt.Errorf("could not write secrets file: %w", err)
+	}
+
+	return nil
+
+}
+
+func (s *FileStore) cipher(salt []byte) (cipher.AEAD, error) {
+
+	key, err := scrypt.Key([]byte(s.Passphrase), salt, scryptN, scryptR, scryptP, scryptLen)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive encryption key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("could not create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("could not create GCM: %w", err)
+	}
+
+	return gcm, nil
+
+}
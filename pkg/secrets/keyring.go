@@ -0,0 +1,41 @@
+package secrets
+
+import (
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// service namespaces every secret naturedopes-cli stores in the OS
+// keychain. go-keyring picks the right backend per platform: macOS
+// Keychain, Windows Credential Manager, or libsecret/kwallet on Linux.
+const service = "naturedopes-cli"
+
+// KeyringStore stores secrets in the OS keychain.
+type KeyringStore struct{}
+
+func NewKeyringStore() *KeyringStore {
+	return &KeyringStore{}
+}
+
+func (s *KeyringStore) Get(name string) (string, error) {
+	value, err := keyring.Get(service, name)
+	if err != nil {
+		return "", fmt.Errorf("could not read %q from OS keychain: %w", name, err)
+	}
+	return value, nil
+}
+
+func (s *KeyringStore) Set(name, value string) error {
+	if err := keyring.Set(service, name, value); err != nil {
+		return fmt.Errorf("could not write %q to OS keychain: %w", name, err)
+	}
+	return nil
+}
+
+func (s *KeyringStore) Delete(name string) error {
+	if err := keyring.Delete(service, name); err != nil {
+		return fmt.Errorf("could not delete %q from OS keychain: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// canaryName is written and immediately deleted to check whether an OS
+// keychain is actually usable in the current environment (e.g. no
+// Secret Service running on a headless Linux box).
+const canaryName = "naturedopes-cli-keychain-check"
+
+// DefaultFilePath is where the encrypted fallback store lives when no OS
+// keychain is available.
+func DefaultFilePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("user home directory not found: %w", err)
+	}
+	return filepath.Join(homeDir, ".naturedopes-cli", "secrets.enc"), nil
+}
+
+// Open returns the OS keychain store if it's usable on this machine,
+// falling back to a passphrase-encrypted file store otherwise.
+func Open(passphrase string) (Store, error) {
+
+	keychain := NewKeyringStore()
+	if err := keychain.Set(canaryName, "ok"); err == nil {
+		_ = keychain.Delete(canaryName)
+		return keychain, nil
+	}
+
+	path, err := DefaultFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	if passphrase == "" {
+		return nil, fmt.Errorf("no OS keychain available and no passphrase supplied for file-backed secret storage (set NATUREDOPES_CLI_PASSPHRASE)")
+	}
+
+	return NewFileStore(path, passphrase), nil
+
+}
@@ -0,0 +1,12 @@
+// Package secrets persists sensitive values (API keys) outside of plain
+// config files, preferring the OS keychain and falling back to an
+// encrypted file when one isn't available.
+package secrets
+
+// Store persists and retrieves secret values by name, independent of the
+// backing mechanism.
+type Store interface {
+	Get(name string) (string, error)
+	Set(name, value string) error
+	Delete(name string) error
+}